@@ -0,0 +1,99 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonstream
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/dreikorn/mongo-go-driver/bson"
+)
+
+// maxDocumentSize bounds the length prefix a Decoder will trust before
+// allocating a buffer for it, so a corrupt or malicious stream can't drive
+// unbounded memory use.
+const maxDocumentSize = 64 * 1024 * 1024
+
+// Decoder reads a flat stream of length-prefixed BSON documents from an
+// io.Reader. A Decoder is not safe for concurrent use.
+type Decoder struct {
+	r    io.Reader
+	pool *bufferPool
+
+	lenBuf  [4]byte
+	peeked  int32
+	hasPeek bool
+}
+
+// NewDecoder returns a Decoder that reads documents from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r, pool: newBufferPool()}
+}
+
+// Peek returns the length, in bytes, of the next document in the stream
+// without consuming it. A subsequent call to Next or Skip reads that same
+// document.
+func (d *Decoder) Peek() (int32, error) {
+	if d.hasPeek {
+		return d.peeked, nil
+	}
+
+	if _, err := io.ReadFull(d.r, d.lenBuf[:]); err != nil {
+		return 0, err
+	}
+	length := int32(binary.LittleEndian.Uint32(d.lenBuf[:]))
+	if length < 5 || int(length) > maxDocumentSize {
+		return 0, fmt.Errorf("bsonstream: invalid document length %d", length)
+	}
+
+	d.peeked = length
+	d.hasPeek = true
+	return length, nil
+}
+
+// Next reads and returns the next document in the stream as a bson.Raw view
+// over a buffer owned by the Decoder. The returned Raw is only valid until
+// the next call to Next or Skip; callers that need to retain it past that
+// point must copy it. Next returns io.EOF when the stream is exhausted.
+func (d *Decoder) Next() (bson.Raw, error) {
+	length, err := d.Peek()
+	if err != nil {
+		return nil, err
+	}
+	d.hasPeek = false
+
+	buf := d.pool.get(int(length))
+	copy(buf, d.lenBuf[:])
+	if _, err := io.ReadFull(d.r, buf[4:]); err != nil {
+		return nil, err
+	}
+	return bson.Raw(buf), nil
+}
+
+// Put returns a bson.Raw previously returned by Next to the Decoder's buffer
+// pool so its backing array can be reused by a later Next call. Callers that
+// copy out of the Raw before moving on should call Put; it is always safe to
+// skip.
+func (d *Decoder) Put(raw bson.Raw) {
+	d.pool.put([]byte(raw))
+}
+
+// Skip discards the next document in the stream without allocating a buffer
+// for its contents.
+func (d *Decoder) Skip() error {
+	length, err := d.Peek()
+	if err != nil {
+		return err
+	}
+	d.hasPeek = false
+
+	if _, err := io.CopyN(io.Discard, d.r, int64(length)-4); err != nil {
+		return err
+	}
+	return nil
+}
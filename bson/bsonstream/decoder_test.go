@@ -0,0 +1,65 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonstream
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/dreikorn/mongo-go-driver/bson"
+	"github.com/dreikorn/mongo-go-driver/internal/testutil/assert"
+)
+
+func TestDecoderRoundTrip(t *testing.T) {
+	t.Run("round trips documents written by Encoder", func(t *testing.T) {
+		docs := []bson.D{
+			{{"_id", int32(1)}, {"x", "a"}},
+			{{"_id", int32(2)}, {"x", "b"}},
+		}
+
+		var buf bytes.Buffer
+		enc := NewEncoder(&buf)
+		for _, doc := range docs {
+			err := enc.Encode(doc)
+			assert.Nil(t, err, "Encode error: %v", err)
+		}
+
+		dec := NewDecoder(&buf)
+		for i, want := range docs {
+			raw, err := dec.Next()
+			assert.Nil(t, err, "Next error: %v", err)
+
+			var got bson.D
+			err = bson.Unmarshal(raw, &got)
+			assert.Nil(t, err, "Unmarshal error: %v", err)
+			assert.Equal(t, want, got, "document %d mismatch: expected %v, got %v", i, want, got)
+		}
+
+		_, err := dec.Next()
+		assert.Equal(t, io.EOF, err, "expected io.EOF, got %v", err)
+	})
+
+	t.Run("Skip advances past a document without decoding it", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := NewEncoder(&buf)
+		assert.Nil(t, enc.Encode(bson.D{{"_id", 1}}), "Encode error")
+		assert.Nil(t, enc.Encode(bson.D{{"_id", 2}}), "Encode error")
+
+		dec := NewDecoder(&buf)
+		err := dec.Skip()
+		assert.Nil(t, err, "Skip error: %v", err)
+
+		raw, err := dec.Next()
+		assert.Nil(t, err, "Next error: %v", err)
+
+		var got bson.D
+		err = bson.Unmarshal(raw, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, bson.D{{"_id", int32(2)}}, got, "expected second document, got %v", got)
+	})
+}
@@ -0,0 +1,11 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package bsonstream reads and writes a flat stream of length-prefixed BSON
+// documents, the format used by mongodump/mongorestore-style archive files.
+// It lets callers feed Collection.InsertMany from a file, or write documents
+// out to one, without holding the whole dataset in memory at once.
+package bsonstream
@@ -0,0 +1,37 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonstream
+
+import (
+	"io"
+
+	"github.com/dreikorn/mongo-go-driver/bson"
+)
+
+// Encoder writes a flat stream of length-prefixed BSON documents to an
+// io.Writer, matching the format Decoder reads. An Encoder is not safe for
+// concurrent use.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder returns an Encoder that writes documents to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode marshals v to BSON and writes it to the stream. v may be anything
+// accepted by bson.Marshal, including a bson.Raw document read from a
+// Decoder.
+func (e *Encoder) Encode(v interface{}) error {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
@@ -0,0 +1,45 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonstream
+
+import "sync"
+
+// minPooledBufferSize is the smallest capacity bufferPool.get allocates;
+// smaller requests are rounded up to it so the buffer can be reused later.
+const minPooledBufferSize = 256
+
+// bufferPool is a bounded pool of byte slices reused across Decoder.Next
+// calls so that decoding a long-running stream doesn't allocate per
+// document.
+type bufferPool struct {
+	pool sync.Pool
+}
+
+func newBufferPool() *bufferPool {
+	return &bufferPool{}
+}
+
+// get returns a buffer with length n, reusing a pooled slice when it's large
+// enough.
+func (p *bufferPool) get(n int) []byte {
+	if v := p.pool.Get(); v != nil {
+		buf := v.([]byte)
+		if cap(buf) >= n {
+			return buf[:n]
+		}
+	}
+	size := n
+	if size < minPooledBufferSize {
+		size = minPooledBufferSize
+	}
+	return make([]byte, n, size)
+}
+
+// put returns buf to the pool for reuse by a later get call.
+func (p *bufferPool) put(buf []byte) {
+	p.pool.Put(buf) //nolint:staticcheck // intentionally pooling the backing array, not buf's current length
+}
@@ -0,0 +1,157 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"github.com/dreikorn/mongo-go-driver/bson"
+	"github.com/dreikorn/mongo-go-driver/mongo/options"
+)
+
+// defaultBulkBufferMaxDocs mirrors the server's default maxWriteBatchSize.
+const defaultBulkBufferMaxDocs = 100000
+
+// defaultBulkBufferMaxBytes mirrors the server's default maxBsonObjectSize,
+// which bounds the size of the command document a batch is encoded into.
+const defaultBulkBufferMaxBytes = 16 * 1024 * 1024
+
+// BufferedBulkWriter accumulates write models in memory and flushes them to
+// the server via Collection.BulkWrite once a configured document count or
+// byte size is reached, so that streaming ETL-style callers don't have to
+// re-implement batch accounting on top of BulkWrite themselves.
+//
+// A BufferedBulkWriter is not safe for concurrent use.
+type BufferedBulkWriter struct {
+	coll     *Collection
+	ordered  bool
+	maxDocs  int
+	maxBytes int
+
+	models   []WriteModel
+	byteSize int
+	inserted int64
+	updated  int64
+	deleted  int64
+}
+
+// BulkBuffer returns a BufferedBulkWriter that flushes to coll once the
+// configured document count or byte size is reached.
+func (coll *Collection) BulkBuffer(opts ...*options.BulkBufferOptions) *BufferedBulkWriter {
+	args := options.MergeBulkBufferOptions(opts...)
+
+	w := &BufferedBulkWriter{
+		coll:     coll,
+		ordered:  true,
+		maxDocs:  defaultBulkBufferMaxDocs,
+		maxBytes: defaultBulkBufferMaxBytes,
+	}
+	if args.Ordered != nil {
+		w.ordered = *args.Ordered
+	}
+	if args.MaxDocs != nil {
+		w.maxDocs = *args.MaxDocs
+	}
+	if args.MaxBytes != nil {
+		w.maxBytes = *args.MaxBytes
+	}
+	return w
+}
+
+// InsertOne buffers an insert of document, flushing first if the buffer is
+// already full.
+func (w *BufferedBulkWriter) InsertOne(ctx context.Context, document interface{}) error {
+	return w.add(ctx, NewInsertOneModel().SetDocument(document), document)
+}
+
+// UpdateOne buffers an update of the first document matching filter,
+// flushing first if the buffer is already full.
+func (w *BufferedBulkWriter) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) error {
+	model := NewUpdateOneModel().SetFilter(filter).SetUpdate(update)
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Upsert != nil {
+			model.SetUpsert(*opt.Upsert)
+		}
+		if opt.Collation != nil {
+			model.SetCollation(opt.Collation)
+		}
+		if opt.ArrayFilters != nil {
+			model.SetArrayFilters(*opt.ArrayFilters)
+		}
+		if opt.Hint != nil {
+			model.SetHint(opt.Hint)
+		}
+	}
+	return w.add(ctx, model, bson.D{{"filter", filter}, {"update", update}})
+}
+
+// DeleteOne buffers a delete of the first document matching filter, flushing
+// first if the buffer is already full.
+func (w *BufferedBulkWriter) DeleteOne(ctx context.Context, filter interface{}) error {
+	return w.add(ctx, NewDeleteOneModel().SetFilter(filter), filter)
+}
+
+func (w *BufferedBulkWriter) add(ctx context.Context, model WriteModel, encodeFor interface{}) error {
+	raw, err := bson.Marshal(encodeFor)
+	if err != nil {
+		return err
+	}
+
+	if len(w.models) >= w.maxDocs || w.byteSize+len(raw) > w.maxBytes {
+		if err := w.Flush(ctx); err != nil {
+			return err
+		}
+	}
+
+	w.models = append(w.models, model)
+	w.byteSize += len(raw)
+	return nil
+}
+
+// Flush sends any buffered models to the server and resets the buffer. It is
+// a no-op if the buffer is empty.
+func (w *BufferedBulkWriter) Flush(ctx context.Context) error {
+	if len(w.models) == 0 {
+		return nil
+	}
+
+	models := w.models
+	w.models = nil
+	w.byteSize = 0
+
+	result, err := w.coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(w.ordered))
+	if result != nil {
+		w.inserted += result.InsertedCount
+		w.updated += result.ModifiedCount + result.UpsertedCount
+		w.deleted += result.DeletedCount
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// InsertedCount returns the total number of documents inserted across every
+// flush so far.
+func (w *BufferedBulkWriter) InsertedCount() int64 {
+	return w.inserted
+}
+
+// UpdatedCount returns the total number of documents updated or upserted
+// across every flush so far.
+func (w *BufferedBulkWriter) UpdatedCount() int64 {
+	return w.updated
+}
+
+// DeletedCount returns the total number of documents deleted across every
+// flush so far.
+func (w *BufferedBulkWriter) DeletedCount() int64 {
+	return w.deleted
+}
@@ -0,0 +1,127 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import "context"
+
+// codeNameError is a sentinel error matched by server codeName rather than by
+// identity, so that callers can write errors.Is(err, mongo.ErrNotWritablePrimary)
+// instead of comparing ce.Name or ce.Code by hand. It is never returned
+// directly by driver code; it only appears as the target of an errors.Is
+// comparison against a CommandError or WriteException.
+type codeNameError string
+
+func (e codeNameError) Error() string { return string(e) }
+
+// Sentinel errors for well-known server codeNames. Additional values should
+// be added here as they come up rather than forcing callers back to string
+// comparisons against CommandError.Name.
+var (
+	ErrInvalidLength       = codeNameError("InvalidLength")
+	ErrNotWritablePrimary  = codeNameError("NotWritablePrimary")
+	ErrWriteConcernTimeout = codeNameError("WriteConcernTimeout")
+	ErrNetworkTimeout      = codeNameError("NetworkTimeout")
+)
+
+// Is implements the interface used by errors.Is. A CommandError matches a
+// codeNameError sentinel when its Name field equals the sentinel's codeName.
+func (e CommandError) Is(target error) bool {
+	cne, ok := target.(codeNameError)
+	if !ok {
+		return false
+	}
+	return e.Name == string(cne)
+}
+
+// CommandError already implements Unwrap (returning Wrapped) elsewhere in
+// this package, so errors.Is/errors.As already continue past it to whatever
+// caused it (for example a context.DeadlineExceeded from a command sent with
+// a timeout); that is not redeclared here. The net.Error-style adapting for
+// codeNames that identify a timeout lives in asTimeoutError below instead, so
+// it composes with the existing Unwrap rather than replacing it.
+
+// Is implements the interface used by errors.Is. A WriteException matches a
+// codeNameError sentinel when its write concern error's Name field equals the
+// sentinel's codeName.
+func (we WriteException) Is(target error) bool {
+	cne, ok := target.(codeNameError)
+	if !ok {
+		return false
+	}
+	return we.WriteConcernError != nil && we.WriteConcernError.Name == string(cne)
+}
+
+// timeoutError adapts a wrapped error to the net.Error-style Timeout/Temporary
+// interface so that callers can tell a transient network condition apart from
+// a permanent one without inspecting driver-internal types.
+type timeoutError struct {
+	wrapped error
+}
+
+// newTimeoutError wraps err, preserving it for errors.Is/errors.As via
+// Unwrap, and reports it as both a timeout and temporary condition.
+func newTimeoutError(err error) error {
+	return timeoutError{wrapped: err}
+}
+
+func (e timeoutError) Error() string   { return e.wrapped.Error() }
+func (e timeoutError) Unwrap() error   { return e.wrapped }
+func (e timeoutError) Timeout() bool   { return true }
+func (e timeoutError) Temporary() bool { return true }
+
+var _ error = timeoutError{}
+
+// asTimeoutError adapts err to the net.Error-style Timeout/Temporary
+// interface when it is a CommandError or WriteException whose codeName
+// identifies a timeout (ErrNetworkTimeout, ErrWriteConcernTimeout); it
+// returns err unchanged otherwise. This is how newTimeoutError gets applied
+// to real driver errors, since CommandError's own Unwrap is left untouched.
+func asTimeoutError(err error) error {
+	switch e := err.(type) {
+	case CommandError:
+		if e.Is(ErrNetworkTimeout) || e.Is(ErrWriteConcernTimeout) {
+			return newTimeoutError(e)
+		}
+	case WriteException:
+		if e.Is(ErrNetworkTimeout) || e.Is(ErrWriteConcernTimeout) {
+			return newTimeoutError(e)
+		}
+	}
+	return err
+}
+
+// IsTimeout reports whether err, or any error wrapped by it, represents a
+// timeout: an error whose Timeout method reports true, a context deadline
+// exceeded while waiting on a server response, or a CommandError or
+// WriteException with the NetworkTimeout or WriteConcernTimeout codeName.
+func IsTimeout(err error) bool {
+	type timeout interface {
+		Timeout() bool
+	}
+
+	for err != nil {
+		if t, ok := err.(timeout); ok && t.Timeout() {
+			return true
+		}
+		if err == context.DeadlineExceeded {
+			return true
+		}
+		if t, ok := asTimeoutError(err).(timeout); ok && t.Timeout() {
+			return true
+		}
+		err = unwrap(err)
+	}
+	return false
+}
+
+func unwrap(err error) error {
+	u, ok := err.(interface{ Unwrap() error })
+	if !ok {
+		return nil
+	}
+	return u.Unwrap()
+}
@@ -0,0 +1,82 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package unified
+
+import (
+	"github.com/dreikorn/mongo-go-driver/bson"
+	"github.com/dreikorn/mongo-go-driver/internal/testutil/assert"
+	"github.com/dreikorn/mongo-go-driver/mongo/integration/mtest"
+	"github.com/dreikorn/mongo-go-driver/mongo/options"
+)
+
+// CollectionData describes the contents a collection is expected to have,
+// either as the "initialData" seeded before a test runs or as the "outcome"
+// asserted after it finishes.
+type CollectionData struct {
+	DatabaseName   string     `bson:"databaseName"`
+	CollectionName string     `bson:"collectionName"`
+	Documents      []bson.Raw `bson:"documents"`
+}
+
+// Seed drops CollectionName in DatabaseName, if it exists, and re-inserts
+// Documents with a majority write concern so that every node in a replica
+// set has observed the initial state before the test body runs.
+func (cd CollectionData) Seed(mt *mtest.T) {
+	mt.Helper()
+
+	collOpts := options.Collection().SetWriteConcern(mtest.MajorityWc)
+	coll := mtest.GlobalClient().Database(cd.DatabaseName).Collection(cd.CollectionName, collOpts)
+
+	err := coll.Drop(mtest.Background)
+	assert.Nil(mt, err, "Drop error for %s.%s: %v", cd.DatabaseName, cd.CollectionName, err)
+
+	if len(cd.Documents) == 0 {
+		return
+	}
+
+	docs := make([]interface{}, len(cd.Documents))
+	for i, doc := range cd.Documents {
+		docs[i] = doc
+	}
+
+	_, err = coll.InsertMany(mtest.Background, docs)
+	assert.Nil(mt, err, "InsertMany error for %s.%s: %v", cd.DatabaseName, cd.CollectionName, err)
+}
+
+// assertOutcome reads back CollectionName sorted by _id and compares it,
+// document for document, against Documents. Documents are compared as
+// decoded bson.M rather than raw bytes, so the comparison is insensitive to
+// field order; it is not yet insensitive to numeric-type normalization (an
+// expected int32 will not match an actual int64 of the same value), which a
+// fuller unified-format document matcher would need to handle.
+func (cd CollectionData) assertOutcome(mt *mtest.T) {
+	mt.Helper()
+
+	coll := mtest.GlobalClient().Database(cd.DatabaseName).Collection(cd.CollectionName)
+
+	cursor, err := coll.Find(mtest.Background, bson.D{}, options.Find().SetSort(bson.D{{"_id", 1}}))
+	assert.Nil(mt, err, "Find error for %s.%s: %v", cd.DatabaseName, cd.CollectionName, err)
+	defer cursor.Close(mtest.Background)
+
+	var actual []bson.M
+	for cursor.Next(mtest.Background) {
+		var doc bson.M
+		err := cursor.Decode(&doc)
+		assert.Nil(mt, err, "Decode error for %s.%s: %v", cd.DatabaseName, cd.CollectionName, err)
+		actual = append(actual, doc)
+	}
+	assert.Nil(mt, cursor.Err(), "cursor error for %s.%s: %v", cd.DatabaseName, cd.CollectionName, cursor.Err())
+
+	assert.Equal(mt, len(cd.Documents), len(actual),
+		"expected %d documents in %s.%s, got %d", len(cd.Documents), cd.DatabaseName, cd.CollectionName, len(actual))
+	for i, wantRaw := range cd.Documents {
+		var want bson.M
+		err := bson.Unmarshal(wantRaw, &want)
+		assert.Nil(mt, err, "Unmarshal error for expected document %d in %s.%s: %v", i, cd.DatabaseName, cd.CollectionName, err)
+		assert.Equal(mt, want, actual[i], "document %d mismatch in %s.%s", i, cd.DatabaseName, cd.CollectionName)
+	}
+}
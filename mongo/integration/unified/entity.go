@@ -0,0 +1,80 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package unified
+
+import (
+	"github.com/dreikorn/mongo-go-driver/internal/testutil/assert"
+	"github.com/dreikorn/mongo-go-driver/mongo"
+	"github.com/dreikorn/mongo-go-driver/mongo/integration/mtest"
+)
+
+// EntityMap describes a single entry in a test file's "createEntities" array.
+// Exactly one of the fields should be set, matching the single key the spec
+// JSON object is expected to have.
+type EntityMap struct {
+	Client     *clientEntity     `bson:"client"`
+	Database   *databaseEntity   `bson:"database"`
+	Collection *collectionEntity `bson:"collection"`
+}
+
+type clientEntity struct {
+	ID string `bson:"id"`
+}
+
+type databaseEntity struct {
+	ID           string `bson:"id"`
+	Client       string `bson:"client"`
+	DatabaseName string `bson:"databaseName"`
+}
+
+type collectionEntity struct {
+	ID             string `bson:"id"`
+	Database       string `bson:"database"`
+	CollectionName string `bson:"collectionName"`
+}
+
+// entities holds the live driver objects created from a test file's
+// "createEntities" array, keyed by their spec-assigned id.
+type entities struct {
+	databases   map[string]*mongo.Database
+	collections map[string]*mongo.Collection
+}
+
+func newEntityMap() *entities {
+	return &entities{
+		databases:   make(map[string]*mongo.Database),
+		collections: make(map[string]*mongo.Collection),
+	}
+}
+
+// create registers a single entity against mtest.GlobalClient(). Every entity
+// hangs off the global client because the runner does not yet support the
+// observeEvents/useMultipleMongoses client options; tests that need those
+// should not be run through RunTests.
+func (e *entities) create(mt *mtest.T, em EntityMap) {
+	mt.Helper()
+
+	switch {
+	case em.Client != nil:
+		// The global client already exists; nothing is recorded for a client entity.
+	case em.Database != nil:
+		db := mtest.GlobalClient().Database(em.Database.DatabaseName)
+		e.databases[em.Database.ID] = db
+	case em.Collection != nil:
+		db, ok := e.databases[em.Collection.Database]
+		assert.True(mt, ok, "unknown database entity %q", em.Collection.Database)
+		e.collections[em.Collection.ID] = db.Collection(em.Collection.CollectionName)
+	}
+}
+
+func (e *entities) collection(mt *mtest.T, id string) *mongo.Collection {
+	mt.Helper()
+
+	coll, ok := e.collections[id]
+	assert.True(mt, ok, "unknown collection entity %q", id)
+	return coll
+}
@@ -0,0 +1,39 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package unified
+
+import (
+	"github.com/dreikorn/mongo-go-driver/internal/testutil/assert"
+	"github.com/dreikorn/mongo-go-driver/mongo/integration/mtest"
+)
+
+// ExpectEvent describes one entry in a test case's "expectEvents" array: the
+// ordered list of command-monitoring events a client is expected to have
+// emitted during the operations that preceded it.
+type ExpectEvent struct {
+	Client string                 `bson:"client"`
+	Events []ExpectedCommandEvent `bson:"events"`
+}
+
+// ExpectedCommandEvent matches a single command-started event by name; spec
+// files that assert on full command documents should extend this struct.
+type ExpectedCommandEvent struct {
+	CommandStartedEvent struct {
+		CommandName string `bson:"commandName"`
+	} `bson:"commandStartedEvent"`
+}
+
+func (ee ExpectEvent) assert(mt *mtest.T) {
+	mt.Helper()
+
+	for _, expected := range ee.Events {
+		evt := mt.GetStartedEvent()
+		assert.NotNil(mt, evt, "expected a command started event, got none")
+		assert.Equal(mt, expected.CommandStartedEvent.CommandName, evt.CommandName,
+			"expected command %q, got %q", expected.CommandStartedEvent.CommandName, evt.CommandName)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package unified
+
+import (
+	"github.com/dreikorn/mongo-go-driver/bson"
+	"github.com/dreikorn/mongo-go-driver/internal/testutil/assert"
+	"github.com/dreikorn/mongo-go-driver/mongo"
+	"github.com/dreikorn/mongo-go-driver/mongo/integration/mtest"
+)
+
+// Operation models a single entry in a test case's "operations" array. The
+// set of supported operations currently covers a handful of CRUD methods;
+// new names should be added here as more spec areas are ported over to this
+// runner.
+type Operation struct {
+	Name      string   `bson:"name"`
+	Object    string   `bson:"object"`
+	Arguments bson.Raw `bson:"arguments"`
+}
+
+func (op Operation) execute(mt *mtest.T, ents *entities) {
+	mt.Helper()
+
+	coll := ents.collection(mt, op.Object)
+
+	switch op.Name {
+	case "insertOne":
+		var args struct {
+			Document bson.Raw `bson:"document"`
+		}
+		decodeArguments(mt, op.Arguments, &args)
+		_, err := coll.InsertOne(mtest.Background, args.Document)
+		assert.Nil(mt, err, "InsertOne error: %v", err)
+	case "find":
+		var args struct {
+			Filter bson.Raw `bson:"filter"`
+		}
+		decodeArguments(mt, op.Arguments, &args)
+		cursor, err := coll.Find(mtest.Background, args.Filter)
+		assert.Nil(mt, err, "Find error: %v", err)
+		defer cursor.Close(mtest.Background)
+		for cursor.Next(mtest.Background) {
+		}
+	case "aggregate":
+		var args struct {
+			Pipeline mongo.Pipeline `bson:"pipeline"`
+		}
+		decodeArguments(mt, op.Arguments, &args)
+		cursor, err := coll.Aggregate(mtest.Background, args.Pipeline)
+		assert.Nil(mt, err, "Aggregate error: %v", err)
+		defer cursor.Close(mtest.Background)
+	case "updateOne":
+		var args struct {
+			Filter bson.Raw `bson:"filter"`
+			Update bson.Raw `bson:"update"`
+		}
+		decodeArguments(mt, op.Arguments, &args)
+		_, err := coll.UpdateOne(mtest.Background, args.Filter, args.Update)
+		assert.Nil(mt, err, "UpdateOne error: %v", err)
+	case "deleteOne":
+		var args struct {
+			Filter bson.Raw `bson:"filter"`
+		}
+		decodeArguments(mt, op.Arguments, &args)
+		_, err := coll.DeleteOne(mtest.Background, args.Filter)
+		assert.Nil(mt, err, "DeleteOne error: %v", err)
+	default:
+		mt.Fatalf("unsupported unified test operation %q", op.Name)
+	}
+}
+
+func decodeArguments(mt *mtest.T, raw bson.Raw, out interface{}) {
+	mt.Helper()
+
+	err := bson.Unmarshal(raw, out)
+	assert.Nil(mt, err, "error decoding arguments for operation: %v", err)
+}
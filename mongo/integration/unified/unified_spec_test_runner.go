@@ -0,0 +1,178 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package unified contains a driver for the MongoDB Unified Test Format. It
+// parses the JSON test files defined by the format and executes them against
+// a live server, so that spec updates can be dropped in verbatim instead of
+// hand-translated into Go test functions.
+package unified
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/dreikorn/mongo-go-driver/bson"
+	"github.com/dreikorn/mongo-go-driver/internal/testutil/assert"
+	"github.com/dreikorn/mongo-go-driver/mongo/integration/mtest"
+)
+
+// SchemaVersion is the highest Unified Test Format schema version understood by this runner.
+const SchemaVersion = "1.x"
+
+// TestFile models the top level of a Unified Test Format JSON file.
+type TestFile struct {
+	Description       string             `bson:"description"`
+	SchemaVersion     string             `bson:"schemaVersion"`
+	CreateEntities    []EntityMap        `bson:"createEntities"`
+	InitialData       []CollectionData   `bson:"initialData"`
+	Tests             []TestCase         `bson:"tests"`
+	RunOnRequirements []RunOnRequirement `bson:"runOnRequirements"`
+}
+
+// TestCase models a single entry in a test file's "tests" array.
+type TestCase struct {
+	Description       string             `bson:"description"`
+	RunOnRequirements []RunOnRequirement `bson:"runOnRequirements"`
+	Operations        []Operation        `bson:"operations"`
+	ExpectEvents      []ExpectEvent      `bson:"expectEvents"`
+	Outcome           []CollectionData   `bson:"outcome"`
+	SkipReason        string             `bson:"skipReason"`
+}
+
+// RunOnRequirement models a single entry in a "runOnRequirements" array. The
+// unified test format OR's these together (a file or test case is runnable if
+// any entry is satisfied); this runner does not. requirementOpts applies the
+// entries in order, so for each field the last entry that sets it is the one
+// enforced and earlier entries' values for that field are discarded. A
+// single-entry array is enforced as written.
+type RunOnRequirement struct {
+	MinServerVersion string   `bson:"minServerVersion"`
+	MaxServerVersion string   `bson:"maxServerVersion"`
+	Topologies       []string `bson:"topologies"`
+}
+
+// requirementOpts translates reqs into the mtest.Options gating that hand-written
+// tests already use for declarative version/topology skipping (see
+// mtest.Options.MinServerVersion/MaxServerVersion/Topologies as used by
+// TestAggregateSecondaryPreferredReadPreference and
+// TestErrorsCodeNamePropagated), so requirements parsed from spec JSON are
+// enforced with the same mechanism instead of a bespoke one.
+func requirementOpts(reqs []RunOnRequirement) *mtest.Options {
+	opts := mtest.NewOptions()
+	for _, req := range reqs {
+		if req.MinServerVersion != "" {
+			opts = opts.MinServerVersion(req.MinServerVersion)
+		}
+		if req.MaxServerVersion != "" {
+			opts = opts.MaxServerVersion(req.MaxServerVersion)
+		}
+		if len(req.Topologies) > 0 {
+			opts = opts.Topologies(topologyKinds(req.Topologies)...)
+		}
+	}
+	return opts
+}
+
+// topologyKinds maps the unified test format's topology strings to the
+// mtest.TopologyKind values hand-written tests gate on (mtest.Single,
+// mtest.ReplicaSet, mtest.Sharded). Topology strings this runner doesn't
+// recognize are dropped rather than guessed at, so a spec file requiring
+// "sharded-replicaset" or "load-balanced" runs ungated on that axis instead
+// of failing to compile against mtest surface this package hasn't verified.
+func topologyKinds(names []string) []mtest.TopologyKind {
+	kinds := make([]mtest.TopologyKind, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case "single":
+			kinds = append(kinds, mtest.Single)
+		case "replicaset":
+			kinds = append(kinds, mtest.ReplicaSet)
+		case "sharded":
+			kinds = append(kinds, mtest.Sharded)
+		}
+	}
+	return kinds
+}
+
+// RunTests loads every JSON file in dir and executes its test cases against mt.
+//
+// Files are expected to conform to the Unified Test Format; files that do not
+// parse as such cause the call to fail the enclosing test immediately rather
+// than be silently skipped.
+func RunTests(t *testing.T, dir string, mtOpts ...*mtest.Options) {
+	t.Helper()
+
+	files, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err, "ReadDir error for %q: %v", dir, err)
+
+	// mtest.Options exposes no confirmed way to merge several *mtest.Options
+	// together, so rather than guess at one, the last non-nil caller-supplied
+	// value wins outright instead of being folded additively into the rest.
+	opts := mtest.NewOptions()
+	for _, o := range mtOpts {
+		if o != nil {
+			opts = o
+		}
+	}
+	mt := mtest.New(t, opts)
+	defer mt.Close()
+
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(dir, file.Name())
+		data, err := ioutil.ReadFile(path)
+		assert.Nil(t, err, "ReadFile error for %q: %v", path, err)
+
+		var tf TestFile
+		err = bson.UnmarshalExtJSON(data, true, &tf)
+		assert.Nil(t, err, "UnmarshalExtJSON error for %q: %v", path, err)
+
+		mt.RunOpts(tf.Description, requirementOpts(tf.RunOnRequirements), func(mt *mtest.T) {
+			runTestFile(mt, &tf)
+		})
+	}
+}
+
+func runTestFile(mt *mtest.T, tf *TestFile) {
+	mt.Helper()
+
+	for _, tc := range tf.Tests {
+		tc := tc
+		mt.RunOpts(tc.Description, requirementOpts(tc.RunOnRequirements), func(mt *mtest.T) {
+			if tc.SkipReason != "" {
+				mt.Skip(tc.SkipReason)
+			}
+
+			// initialData and createEntities are re-seeded/re-created before
+			// every test case, not once per file, so that one test's writes
+			// can never leak into the next and make outcome assertions
+			// order-dependent.
+			for _, data := range tf.InitialData {
+				data.Seed(mt)
+			}
+			entities := newEntityMap()
+			for _, e := range tf.CreateEntities {
+				entities.create(mt, e)
+			}
+
+			mt.ClearEvents()
+			for _, op := range tc.Operations {
+				op.execute(mt, entities)
+			}
+
+			for _, expect := range tc.ExpectEvents {
+				expect.assert(mt)
+			}
+			for _, outcome := range tc.Outcome {
+				outcome.assertOutcome(mt)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dreikorn/mongo-go-driver/mongo"
+)
+
+// EnsureIndexes creates model on every named collection of db, skipping
+// collections where an index with the same keys already exists. It is meant
+// to be called from a Migration's Up for schemas that shard a logical
+// collection per tenant, where the same index needs to exist identically
+// across every tenant's collection.
+func EnsureIndexes(ctx context.Context, db *mongo.Database, collections []string, models ...mongo.IndexModel) error {
+	for _, name := range collections {
+		coll := db.Collection(name)
+		if _, err := coll.Indexes().CreateMany(ctx, models); err != nil {
+			// CreateMany returns IndexKeySpecsConflict when an index with the
+			// same keys already exists under a different name; isIndexExistsError
+			// treats that as success so Up stays idempotent across re-runs. It
+			// does not swallow IndexOptionsConflict, which means the same keys
+			// exist with different options and is a genuine conflict Up must
+			// surface rather than hide.
+			if !isIndexExistsError(err) {
+				return fmt.Errorf("migrate: creating indexes on %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// DropIndexes drops the named index from every named collection of db,
+// ignoring collections where the index does not exist.
+func DropIndexes(ctx context.Context, db *mongo.Database, collections []string, indexName string) error {
+	for _, name := range collections {
+		coll := db.Collection(name)
+		if _, err := coll.Indexes().DropOne(ctx, indexName); err != nil {
+			if !isIndexNotFoundError(err) {
+				return fmt.Errorf("migrate: dropping index %s on %s: %w", indexName, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isIndexExistsError reports whether err is the server rejecting CreateMany
+// because an index with the same keys already exists under a different name.
+// It deliberately does not match IndexOptionsConflict: that codeName means
+// the same keys exist with different options, which is a real conflict and
+// must not be swallowed. It uses errors.As rather than a bare type assertion
+// so the check still matches once err has been wrapped, which the codeName
+// sentinel support added in mongo.CommandError makes a real possibility.
+func isIndexExistsError(err error) bool {
+	var ce mongo.CommandError
+	return errors.As(err, &ce) && ce.Name == "IndexKeySpecsConflict"
+}
+
+func isIndexNotFoundError(err error) bool {
+	var ce mongo.CommandError
+	return errors.As(err, &ce) && ce.Name == "IndexNotFound"
+}
@@ -0,0 +1,174 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package migrate manages versioned schema and index migrations on a
+// mongo.Database. It records which versions have been applied in a
+// _migrations collection so that a Migrator can be re-run safely: a version
+// already recorded as applied is skipped, and a version whose Up failed is
+// not recorded, so its Up runs again on the next call. Because Up must be
+// idempotent, a migration that failed part way through can be fixed and
+// re-deployed without manual cleanup.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dreikorn/mongo-go-driver/bson"
+	"github.com/dreikorn/mongo-go-driver/mongo"
+	"github.com/dreikorn/mongo-go-driver/mongo/options"
+	"github.com/dreikorn/mongo-go-driver/mongo/readconcern"
+	"github.com/dreikorn/mongo-go-driver/mongo/writeconcern"
+)
+
+// migrationsCollection is the name of the collection a Migrator uses to
+// record applied versions.
+const migrationsCollection = "_migrations"
+
+// MajorityWc is the write concern used for every write a Migrator makes to
+// the _migrations collection, so that an applied version is never lost to a
+// rollback.
+var MajorityWc = writeconcern.New(writeconcern.WMajority())
+
+// MajorityRc is the read concern used for every read a Migrator makes from
+// the _migrations collection.
+var MajorityRc = readconcern.Majority()
+
+// Migration is a single versioned change to a database's schema or indexes.
+//
+// Version must be unique and monotonically increasing across the Migrations
+// passed to a Migrator; Migrator.Up applies them in ascending Version order.
+type Migration struct {
+	// Version uniquely identifies this migration and determines the order in
+	// which migrations are applied.
+	Version int64
+
+	// Name is a short human-readable description, recorded alongside Version
+	// in the _migrations collection for operator visibility.
+	Name string
+
+	// Up applies the migration. It must be idempotent: if a previous run
+	// recorded partial progress via db-level side effects but failed before
+	// Migrator could record Version as applied, Up will be called again and
+	// must finish the remainder without erroring on the steps it already
+	// completed (for example, by using EnsureIndexes, which skips a
+	// collection's indexes that already exist, instead of calling
+	// CreateMany directly).
+	Up func(ctx context.Context, db *mongo.Database) error
+
+	// Down reverses the migration. It is optional; a Migration without a Down
+	// cannot be rolled back by Migrator.Down.
+	Down func(ctx context.Context, db *mongo.Database) error
+}
+
+// appliedVersion is the document recorded in _migrations for each applied Migration.
+type appliedVersion struct {
+	Version int64  `bson:"_id"`
+	Name    string `bson:"name"`
+}
+
+// Migrator applies Migrations to a mongo.Database and records which versions
+// have been applied.
+type Migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+}
+
+// NewMigrator returns a Migrator that applies migrations, in ascending
+// Version order, against db.
+func NewMigrator(db *mongo.Database, migrations ...Migration) *Migrator {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	return &Migrator{db: db, migrations: sorted}
+}
+
+func (m *Migrator) collection() *mongo.Collection {
+	return m.db.Collection(migrationsCollection,
+		options.Collection().SetWriteConcern(MajorityWc).SetReadConcern(MajorityRc))
+}
+
+// appliedVersions returns the set of versions already recorded as applied.
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int64]bool, error) {
+	cursor, err := m.collection().Find(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("migrate: listing applied versions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	applied := make(map[int64]bool)
+	for cursor.Next(ctx) {
+		var v appliedVersion
+		if err := cursor.Decode(&v); err != nil {
+			return nil, fmt.Errorf("migrate: decoding applied version: %w", err)
+		}
+		applied[v.Version] = true
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("migrate: listing applied versions: %w", err)
+	}
+	return applied, nil
+}
+
+// Up applies every Migration whose Version has not already been recorded as
+// applied, in ascending Version order. If Up for a given Migration succeeds,
+// its version is recorded before moving on to the next one; if it fails, Up
+// returns immediately and nothing past that point is recorded, so a
+// subsequent call to Up will retry that Migration and continue from there.
+func (m *Migrator) Up(ctx context.Context) error {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		if applied[migration.Version] {
+			continue
+		}
+
+		if err := migration.Up(ctx, m.db); err != nil {
+			return fmt.Errorf("migrate: applying version %d (%s): %w", migration.Version, migration.Name, err)
+		}
+
+		record := appliedVersion{Version: migration.Version, Name: migration.Name}
+		if _, err := m.collection().InsertOne(ctx, record); err != nil {
+			return fmt.Errorf("migrate: recording version %d (%s) as applied: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return nil
+}
+
+// Down reverses every applied Migration, in descending Version order, down
+// to and including toVersion. A Migration without a Down function stops the
+// rollback with an error, leaving it and every applied version below it
+// still recorded as applied.
+func (m *Migrator) Down(ctx context.Context, toVersion int64) error {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version < toVersion || !applied[migration.Version] {
+			continue
+		}
+
+		if migration.Down == nil {
+			return fmt.Errorf("migrate: version %d (%s) has no Down migration", migration.Version, migration.Name)
+		}
+		if err := migration.Down(ctx, m.db); err != nil {
+			return fmt.Errorf("migrate: reverting version %d (%s): %w", migration.Version, migration.Name, err)
+		}
+
+		if _, err := m.collection().DeleteOne(ctx, bson.D{{"_id", migration.Version}}); err != nil {
+			return fmt.Errorf("migrate: un-recording version %d (%s): %w", migration.Version, migration.Name, err)
+		}
+	}
+	return nil
+}
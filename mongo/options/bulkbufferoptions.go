@@ -0,0 +1,68 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// BulkBufferOptions represents options that can be used to configure a
+// BufferedBulkWriter returned by Collection.BulkBuffer.
+type BulkBufferOptions struct {
+	// Ordered controls whether the buffered models are sent to the server as
+	// an ordered or unordered bulk write on each flush. The default is true.
+	Ordered *bool
+
+	// MaxDocs is the maximum number of buffered models before Flush is called
+	// automatically. The default matches the server's maxWriteBatchSize.
+	MaxDocs *int
+
+	// MaxBytes is the maximum total encoded size, in bytes, of the buffered
+	// models before Flush is called automatically. The default matches the
+	// server's maxBsonObjectSize.
+	MaxBytes *int
+}
+
+// BulkBuffer creates a new BulkBufferOptions instance.
+func BulkBuffer() *BulkBufferOptions {
+	return &BulkBufferOptions{}
+}
+
+// SetOrdered sets the value for the Ordered field.
+func (b *BulkBufferOptions) SetOrdered(ordered bool) *BulkBufferOptions {
+	b.Ordered = &ordered
+	return b
+}
+
+// SetMaxDocs sets the value for the MaxDocs field.
+func (b *BulkBufferOptions) SetMaxDocs(maxDocs int) *BulkBufferOptions {
+	b.MaxDocs = &maxDocs
+	return b
+}
+
+// SetMaxBytes sets the value for the MaxBytes field.
+func (b *BulkBufferOptions) SetMaxBytes(maxBytes int) *BulkBufferOptions {
+	b.MaxBytes = &maxBytes
+	return b
+}
+
+// MergeBulkBufferOptions combines the given BulkBufferOptions instances into
+// a single BulkBufferOptions in a last-one-wins fashion.
+func MergeBulkBufferOptions(opts ...*BulkBufferOptions) *BulkBufferOptions {
+	b := BulkBuffer()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Ordered != nil {
+			b.Ordered = opt.Ordered
+		}
+		if opt.MaxDocs != nil {
+			b.MaxDocs = opt.MaxDocs
+		}
+		if opt.MaxBytes != nil {
+			b.MaxBytes = opt.MaxBytes
+		}
+	}
+	return b
+}